@@ -0,0 +1,54 @@
+package main
+
+import "time"
+
+// shortDisplayThreshold is how little display time a trailing cue can
+// have before ClampToDuration treats it as a missing end time.
+const shortDisplayThreshold = 100 * time.Millisecond
+
+// ClampToDuration truncates any cue whose end exceeds total to end at
+// total, drops cues that start at or after total, and extends a
+// trailing cue with implausibly short display time (< shortDisplayThreshold)
+// up to total. This addresses VTT-converted files where the last cue
+// has no real end time.
+func (s *Subtitles) ClampToDuration(total time.Duration) {
+	end := ZeroTime.Add(total)
+
+	kept := make([]*Cue, 0, len(s.Cues))
+	for _, cue := range s.Cues {
+		if !cue.start.Before(end) {
+			continue
+		}
+		if cue.end.After(end) {
+			cue.end = end
+		}
+		kept = append(kept, cue)
+	}
+	s.Cues = kept
+
+	if n := len(s.Cues); n > 0 {
+		last := s.Cues[n-1]
+		if last.end.Sub(last.start) < shortDisplayThreshold {
+			last.end = end
+		}
+	}
+}
+
+// EnforceMinDisplayTime extends each cue's end to at least start+min,
+// without running past the following cue's start.
+func (s *Subtitles) EnforceMinDisplayTime(min time.Duration) {
+	for i, cue := range s.Cues {
+		wanted := cue.start.Add(min)
+		if !wanted.After(cue.end) {
+			continue
+		}
+
+		if i+1 < len(s.Cues) && wanted.After(s.Cues[i+1].start) {
+			wanted = s.Cues[i+1].start
+		}
+
+		if wanted.After(cue.end) {
+			cue.end = wanted
+		}
+	}
+}
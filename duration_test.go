@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClampToDuration(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 2, "kept, ends before total"),
+		cueAt(5, 5.05, "trailing cue with implausibly short display time"),
+		cueAt(20, 21, "starts past total, dropped"),
+	}}
+
+	subs.ClampToDuration(10 * time.Second)
+
+	if len(subs.Cues) != 2 {
+		t.Fatalf("got %d cues, want 2", len(subs.Cues))
+	}
+	if got := subs.Cues[0].end.Sub(ZeroTime); got != 2*time.Second {
+		t.Errorf("first cue end = %v, want unchanged at 2s", got)
+	}
+	if got := subs.Cues[1].end.Sub(ZeroTime); got != 10*time.Second {
+		t.Errorf("trailing cue end = %v, want extended to total 10s", got)
+	}
+}
+
+func TestClampToDurationTruncatesOverrunningCue(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 15, "runs past total"),
+	}}
+
+	subs.ClampToDuration(10 * time.Second)
+
+	if got := subs.Cues[0].end.Sub(ZeroTime); got != 10*time.Second {
+		t.Errorf("cue end = %v, want clamped to 10s", got)
+	}
+}
+
+func TestEnforceMinDisplayTime(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 0.2, "too short"),
+		cueAt(1, 2, "already long enough"),
+	}}
+
+	subs.EnforceMinDisplayTime(500 * time.Millisecond)
+
+	if got := subs.Cues[0].end.Sub(subs.Cues[0].start); got != 500*time.Millisecond {
+		t.Errorf("first cue duration = %v, want 500ms", got)
+	}
+	if got := subs.Cues[1].end.Sub(subs.Cues[1].start); got != time.Second {
+		t.Errorf("second cue duration = %v, want unchanged at 1s", got)
+	}
+}
+
+func TestEnforceMinDisplayTimeDoesNotRunPastNextCue(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 0.2, "too short"),
+		cueAt(0.3, 1, "next cue starts soon"),
+	}}
+
+	subs.EnforceMinDisplayTime(500 * time.Millisecond)
+
+	if got := subs.Cues[0].end.Sub(ZeroTime); got != 300*time.Millisecond {
+		t.Errorf("first cue end = %v, want clamped to next cue's start at 300ms", got)
+	}
+}
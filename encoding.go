@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	stdunicode "unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+// defaultCharsets is probed, in order, when a subtitle file has no BOM
+// and isn't valid UTF-8. Order only matters as a tie-break between
+// candidates that score identically, so the broadly-applicable Western
+// codepage comes first. Windows-1256 (Arabic) precedes Windows-1251:
+// Arabic text decoded through Windows-1251 comes out as nothing but
+// Cyrillic letters and ties with the correct decoding, while Cyrillic
+// text decoded through Windows-1256 mixes Arabic and Latin letters and
+// scores lower.
+var defaultCharsets = []encoding.Encoding{
+	charmap.Windows1252,
+	charmap.Windows1256,
+	charmap.Windows1251,
+	charmap.Windows1250,
+	charmap.ISO8859_1,
+}
+
+// NewReader returns a utf-8 compatible Reader, detecting the source
+// encoding via BOM sniffing and, failing that, charset probing against
+// defaultCharsets.
+func NewReader(r io.Reader) io.Reader {
+	reader, _, err := NewReaderWithCharsets(r, defaultCharsets)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return reader
+}
+
+// NewReaderWithCharsets returns a utf-8 compatible Reader along with the
+// encoding it detected. It first sniffs a UTF-8/UTF-16/UTF-32 BOM; if
+// none is present and the bytes aren't valid UTF-8, it decodes the input
+// with each of candidates in turn and keeps whichever scores best on a
+// script-homogeneity heuristic.
+func NewReaderWithCharsets(r io.Reader, candidates []encoding.Encoding) (io.Reader, encoding.Encoding, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if enc, ok := sniffBOM(data); ok {
+		return enc.NewDecoder().Reader(bytes.NewReader(data)), enc, nil
+	}
+
+	if utf8.Valid(data) {
+		return bytes.NewReader(data), unicode.UTF8, nil
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil, errors.New("no candidate charsets to probe")
+	}
+
+	var best encoding.Encoding
+	bestScore := -1.0
+
+	for _, candidate := range candidates {
+		decoded, err := candidate.NewDecoder().Bytes(data)
+		if err != nil {
+			continue
+		}
+
+		if score := charsetScore(decoded); score > bestScore {
+			bestScore = score
+			best = candidate
+		}
+	}
+
+	if best == nil {
+		return nil, nil, errors.New("could not detect a usable charset")
+	}
+
+	return best.NewDecoder().Reader(bytes.NewReader(data)), best, nil
+}
+
+// sniffBOM detects a leading UTF-8/UTF-16/UTF-32 byte order mark and
+// returns the encoding it implies.
+func sniffBOM(data []byte) (encoding.Encoding, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return unicode.UTF8, true
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE, 0x00, 0x00}):
+		return utf32.UTF32(utf32.LittleEndian, utf32.ExpectBOM), true
+	case bytes.HasPrefix(data, []byte{0x00, 0x00, 0xFE, 0xFF}):
+		return utf32.UTF32(utf32.BigEndian, utf32.ExpectBOM), true
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), true
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), true
+	}
+	return nil, false
+}
+
+// scoredScripts are the scripts charsetScore tracks separately, in a
+// fixed order so bucket assignment is deterministic; any letter outside
+// these (Han, Hangul, ...) is lumped into "other" for homogeneity
+// purposes.
+var scoredScripts = []struct {
+	name  string
+	table *stdunicode.RangeTable
+}{
+	{"Latin", stdunicode.Latin},
+	{"Cyrillic", stdunicode.Cyrillic},
+	{"Arabic", stdunicode.Arabic},
+	{"Greek", stdunicode.Greek},
+	{"Hebrew", stdunicode.Hebrew},
+}
+
+// latinExtendedRatioLimit is how much of a dominant Latin bucket is
+// allowed to come from non-ASCII (accented/extended) letters before
+// charsetScore treats it as implausible.
+const latinExtendedRatioLimit = 0.5
+
+// charsetScore scores a decoded candidate by how much a single script
+// dominates its letters, penalized by (a) the fraction of undefined byte
+// positions (decoded as utf8.RuneError), and (b) a dominant Latin bucket
+// built almost entirely out of non-ASCII letters.
+//
+// (a) alone isn't enough: every single-byte Western codepage maps
+// virtually any byte to *some* Latin letter, so decoding genuine Arabic
+// or Cyrillic text through Windows-1252 scores the same perfect
+// homogeneity as decoding genuine Western text through it, and the two
+// candidates tie. Real Latin-script prose, though, is overwhelmingly
+// plain-ASCII letters with a minority of accented ones; a text whose
+// "Latin" letters are mostly non-ASCII is the signature of a Western
+// codepage misapplied to a different script, not of real Latin prose.
+func charsetScore(data []byte) float64 {
+	runes := []rune(string(data))
+	if len(runes) == 0 {
+		return 0
+	}
+
+	undefined := 0
+	letters := 0
+	counts := map[string]int{}
+	asciiLatin, extendedLatin := 0, 0
+
+	for _, r := range runes {
+		if r == utf8.RuneError {
+			undefined++
+			continue
+		}
+		if !stdunicode.IsLetter(r) {
+			continue
+		}
+
+		letters++
+		matched := "other"
+		for _, script := range scoredScripts {
+			if stdunicode.In(r, script.table) {
+				matched = script.name
+				break
+			}
+		}
+		counts[matched]++
+
+		if matched == "Latin" {
+			if r < 0x80 {
+				asciiLatin++
+			} else {
+				extendedLatin++
+			}
+		}
+	}
+
+	homogeneity := 1.0
+	dominant := ""
+	if letters > 0 {
+		best := 0
+		for _, script := range scoredScripts {
+			if count := counts[script.name]; count > best {
+				best = count
+				dominant = script.name
+			}
+		}
+		if count := counts["other"]; count > best {
+			best = count
+			dominant = "other"
+		}
+		homogeneity = float64(best) / float64(letters)
+	}
+
+	score := homogeneity - float64(undefined)/float64(len(runes))
+
+	if dominant == "Latin" {
+		if latinTotal := asciiLatin + extendedLatin; latinTotal > 0 {
+			if extendedRatio := float64(extendedLatin) / float64(latinTotal); extendedRatio > latinExtendedRatioLimit {
+				score -= extendedRatio
+			}
+		}
+	}
+
+	return score
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestNewReaderWithCharsetsPrefersUTF8(t *testing.T) {
+	reader, enc, err := NewReaderWithCharsets(bytes.NewReader([]byte("hello world")), defaultCharsets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enc != unicode.UTF8 {
+		t.Errorf("detected encoding = %v, want UTF-8", enc)
+	}
+
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() unexpected error: %v", err)
+	}
+	if string(got) != "hello world" {
+		t.Errorf("got %q, want %q", got, "hello world")
+	}
+}
+
+func TestNewReaderWithCharsetsDetectsCharset(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		enc        *charmap.Charmap
+		candidates []encoding.Encoding
+	}{
+		{
+			name:       "western european",
+			text:       "café déjà vu — seen at a glance, nothing more to it",
+			enc:        charmap.Windows1252,
+			candidates: defaultCharsets,
+		},
+		{
+			name:       "arabic",
+			text:       "مرحبا بالعالم، هذا اختبار بسيط للترميز",
+			enc:        charmap.Windows1256,
+			candidates: defaultCharsets,
+		},
+		{
+			name:       "cyrillic",
+			text:       "Привет, как дела? Это простой тест кодировки",
+			enc:        charmap.Windows1251,
+			candidates: defaultCharsets,
+		},
+		{
+			name:       "greek",
+			text:       "Γειά σου κόσμε, αυτή είναι μια απλή δοκιμή",
+			enc:        charmap.Windows1253,
+			candidates: []encoding.Encoding{charmap.Windows1252, charmap.Windows1253},
+		},
+		{
+			name:       "hebrew",
+			text:       "שלום עולם, זהו מבחן קידוד פשוט",
+			enc:        charmap.Windows1255,
+			candidates: []encoding.Encoding{charmap.Windows1252, charmap.Windows1255},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.enc.NewEncoder().String(tt.text)
+			if err != nil {
+				t.Fatalf("failed to encode fixture: %v", err)
+			}
+
+			reader, enc, err := NewReaderWithCharsets(bytes.NewReader([]byte(encoded)), tt.candidates)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if enc != encoding.Encoding(tt.enc) {
+				t.Errorf("detected encoding = %v, want %v", enc, tt.enc)
+			}
+
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("ReadAll() unexpected error: %v", err)
+			}
+			if string(got) != tt.text {
+				t.Errorf("decoded = %q, want %q", got, tt.text)
+			}
+		})
+	}
+}
+
+func TestNewReaderWithCharsetsNoCandidates(t *testing.T) {
+	invalidUTF8 := []byte{0xC0, 0x80}
+	if _, _, err := NewReaderWithCharsets(bytes.NewReader(invalidUTF8), nil); err == nil {
+		t.Fatal("expected error when no candidate charsets are given")
+	}
+}
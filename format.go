@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Reader parses a subtitle file in a specific format into format-neutral
+// Subtitles.
+type Reader interface {
+	Read(r io.Reader) (*Subtitles, error)
+}
+
+// Writer serializes format-neutral Subtitles into a specific format.
+type Writer interface {
+	Write(w io.Writer, subs *Subtitles) error
+}
+
+// format is implemented by every supported subtitle format.
+type format interface {
+	Reader
+	Writer
+}
+
+// formats maps a lowercase file extension to the format that handles it.
+var formats = map[string]format{
+	".srt": srtFormat{},
+	".vtt": vttFormat{},
+	".ssa": ssaFormat{},
+	".ass": ssaFormat{},
+}
+
+// formatFor returns the format registered for path's extension.
+func formatFor(path string) (format, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	f, ok := formats[ext]
+	if !ok {
+		return nil, fmt.Errorf("unsupported subtitle format %q", ext)
+	}
+	return f, nil
+}
+
+// Open reads a subtitle file, picking the format by its file extension
+// (.srt, .vtt, .ssa, .ass).
+func Open(path string) (*Subtitles, error) {
+	f, err := formatFor(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return f.Read(file)
+}
+
+// WriteFile writes s to path, picking the format by its file extension
+// (.srt, .vtt, .ssa, .ass).
+func (s *Subtitles) WriteFile(path string) error {
+	f, err := formatFor(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return f.Write(file, s)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// OverlapStrategy selects how FixOverlaps resolves two cues that overlap
+// in time.
+type OverlapStrategy int
+
+const (
+	// OverlapTrimPrevious clamps the earlier cue's end to just before
+	// the later cue's start.
+	OverlapTrimPrevious OverlapStrategy = iota
+	// OverlapSplit moves the later cue's start to just after the
+	// earlier cue's end.
+	OverlapSplit
+	// OverlapMerge concatenates the two cues' text into one when the
+	// overlap is large relative to the shorter cue's duration.
+	OverlapMerge
+)
+
+const (
+	minGap         = time.Millisecond
+	minCueDuration = 500 * time.Millisecond
+	mergeThreshold = 0.5
+)
+
+// FixOverlaps sorts cues by start time and walks them pairwise, resolving
+// any cue that starts before the previous cue's end (plus minGap)
+// according to strategy, then extends any cue shorter than
+// minCueDuration where room allows. It returns the number of cues
+// adjusted.
+func (s *Subtitles) FixOverlaps(strategy OverlapStrategy) int {
+	sort.Slice(s.Cues, func(i, j int) bool {
+		return s.Cues[i].start.Before(s.Cues[j].start)
+	})
+
+	adjusted := 0
+
+	for i := 1; i < len(s.Cues); i++ {
+		prev := s.Cues[i-1]
+		cur := s.Cues[i]
+
+		if !cur.start.Before(prev.end.Add(minGap)) {
+			continue
+		}
+
+		overlap := prev.end.Sub(cur.start) + minGap
+		shorterDur := cur.end.Sub(cur.start)
+		if prevDur := prev.end.Sub(prev.start); prevDur < shorterDur {
+			shorterDur = prevDur
+		}
+
+		switch strategy {
+		case OverlapMerge:
+			if shorterDur > 0 && float64(overlap)/float64(shorterDur) > mergeThreshold {
+				if cur.end.After(prev.end) {
+					prev.end = cur.end
+				}
+				prev.text = append(prev.text, cur.text...)
+				s.Cues = append(s.Cues[:i], s.Cues[i+1:]...)
+				i--
+				adjusted++
+				continue
+			}
+			fallthrough
+		case OverlapSplit:
+			cur.start = prev.end.Add(minGap)
+			adjusted++
+		case OverlapTrimPrevious:
+			prev.end = cur.start.Add(-minGap)
+			adjusted++
+		}
+	}
+
+	for _, cue := range s.Cues {
+		if cue.end.Sub(cue.start) < minCueDuration {
+			cue.end = cue.start.Add(minCueDuration)
+		}
+	}
+
+	enforceMinGaps(s.Cues)
+
+	return adjusted
+}
+
+// enforceMinGaps trims any cue's end that now runs past the following
+// cue's start after the minCueDuration extension above.
+func enforceMinGaps(cues []*Cue) {
+	for i := 1; i < len(cues); i++ {
+		prev := cues[i-1]
+		cur := cues[i]
+		if prev.end.Add(minGap).After(cur.start) {
+			prev.end = cur.start.Add(-minGap)
+		}
+	}
+}
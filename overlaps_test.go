@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func cueAt(startSec, endSec float64, text ...string) *Cue {
+	return &Cue{
+		start: ZeroTime.Add(time.Duration(startSec * float64(time.Second))),
+		end:   ZeroTime.Add(time.Duration(endSec * float64(time.Second))),
+		text:  text,
+	}
+}
+
+func TestFixOverlapsTrimPrevious(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 3, "one"),
+		cueAt(2, 5, "two"),
+	}}
+
+	adjusted := subs.FixOverlaps(OverlapTrimPrevious)
+
+	if adjusted != 1 {
+		t.Fatalf("adjusted = %d, want 1", adjusted)
+	}
+	if !subs.Cues[0].end.Before(subs.Cues[1].start) {
+		t.Errorf("cue 0 end %v should be before cue 1 start %v", subs.Cues[0].end, subs.Cues[1].start)
+	}
+}
+
+func TestFixOverlapsSplit(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 3, "one"),
+		cueAt(2, 5, "two"),
+	}}
+
+	adjusted := subs.FixOverlaps(OverlapSplit)
+
+	if adjusted != 1 {
+		t.Fatalf("adjusted = %d, want 1", adjusted)
+	}
+	if !subs.Cues[1].start.After(subs.Cues[0].end.Add(-minGap)) {
+		t.Errorf("cue 1 start %v should be at/after cue 0 end %v", subs.Cues[1].start, subs.Cues[0].end)
+	}
+}
+
+func TestFixOverlapsMerge(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 4, "one"),
+		cueAt(1, 2, "two"),
+	}}
+
+	adjusted := subs.FixOverlaps(OverlapMerge)
+
+	if adjusted != 1 {
+		t.Fatalf("adjusted = %d, want 1", adjusted)
+	}
+	if len(subs.Cues) != 1 {
+		t.Fatalf("got %d cues, want 1 merged cue", len(subs.Cues))
+	}
+	if want := []string{"one", "two"}; len(subs.Cues[0].text) != 2 || subs.Cues[0].text[0] != want[0] || subs.Cues[0].text[1] != want[1] {
+		t.Errorf("merged text = %v, want %v", subs.Cues[0].text, want)
+	}
+}
+
+func TestFixOverlapsExtendsShortCues(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 0.2, "short"),
+	}}
+
+	subs.FixOverlaps(OverlapTrimPrevious)
+
+	if got := subs.Cues[0].end.Sub(subs.Cues[0].start); got < minCueDuration {
+		t.Errorf("cue duration = %v, want at least %v", got, minCueDuration)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	srtTimeSeparator = " --> "
+	newLine          = "\n"
+	utf8BOM          = "\uFEFF"
+
+	// srtPositionRe matches the optional X1/X2/Y1/Y2 position directive
+	// SubRip allows after the timestamps on a timing line.
+	srtPositionRe = regexp.MustCompile(`X1:\d+\s+X2:\d+\s+Y1:\d+\s+Y2:\d+`)
+)
+
+// srtFormat implements Reader and Writer for SubRip (.srt) files.
+type srtFormat struct{}
+
+// Read loads SubRip cues. It tolerates files that are missing their
+// index line, carry a leading UTF-8 BOM, or use CRLF line endings.
+func (srtFormat) Read(r io.Reader) (*Subtitles, error) {
+	subs := NewSubtitles()
+
+	reader := NewReader(r)
+	scanner := bufio.NewScanner(reader)
+
+	cue := &Cue{}
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+
+		if strings.Contains(line, srtTimeSeparator) {
+			// drop the index line preceding the timing line, but only
+			// if it's actually an index; some real-world files omit it
+			if len(cue.text) > 0 {
+				if _, err := strconv.Atoi(strings.TrimSpace(cue.text[len(cue.text)-1])); err == nil {
+					cue.text = cue.text[:len(cue.text)-1]
+				}
+			}
+
+			if len(cue.text) > 0 && len(cue.text[len(cue.text)-1]) == 0 {
+				cue.text = cue.text[:len(cue.text)-1]
+			}
+
+			start, end, err := parseSubTime(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: can't parse srt duration %s, %s", lineNum, line, err)
+			}
+			cue = &Cue{
+				start: start,
+				end:   end,
+			}
+			if pos := srtPositionRe.FindString(line); pos != "" {
+				cue.style = &Style{Position: pos}
+			}
+
+			subs.Cues = append(subs.Cues, cue)
+			cue.text = make([]string, 0)
+		} else {
+			cue.text = append(cue.text, line)
+		}
+	}
+	return subs, nil
+}
+
+// Write writes formatted SubRip cues to a writer
+func (srtFormat) Write(w io.Writer, subs *Subtitles) error {
+	if len(subs.Cues) == 0 {
+		return errors.New("no subtitles to write")
+	}
+
+	var buf strings.Builder
+
+	for i, cue := range subs.Cues {
+		buf.WriteString(fmt.Sprintf("%d", i+1) + newLine)
+		buf.WriteString(formatSubTime(cue.start, cue.end))
+		if cue.style != nil && srtPositionRe.MatchString(cue.style.Position) {
+			buf.WriteString(" " + cue.style.Position)
+		}
+		buf.WriteString(newLine)
+		for _, line := range cue.text {
+			buf.WriteString(line + newLine)
+		}
+		buf.WriteString(newLine)
+	}
+
+	if _, err := w.Write([]byte(buf.String())); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseSubTime reads subtitle time to go time. Both "," and "." are
+// accepted as the millisecond separator, since VTT-flavored SRT exports
+// commonly use a dot.
+func parseSubTime(str string) (start, end time.Time, err error) {
+	var h1, m1, s1, ms1, h2, m2, s2, ms2 time.Duration
+	start, end = ZeroTime, ZeroTime
+
+	normalized := strings.ReplaceAll(str, ".", ",")
+
+	_, err = fmt.Sscanf(normalized, "%d:%d:%d,%d --> %d:%d:%d,%d",
+		&h1, &m1, &s1, &ms1,
+		&h2, &m2, &s2, &ms2)
+	if err != nil {
+		return
+	}
+
+	start = start.Add(h1*time.Hour + m1*time.Minute + s1*time.Second + ms1*time.Millisecond)
+	end = end.Add(h2*time.Hour + m2*time.Minute + s2*time.Second + ms2*time.Millisecond)
+
+	return
+}
+
+// formatSubTime formats given start/end times to subtitle time format
+func formatSubTime(start time.Time, end time.Time) string {
+	if start.Before(ZeroTime) {
+		start = ZeroTime
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d,%03d --> %02d:%02d:%02d,%03d",
+		start.Hour(), start.Minute(), start.Second(), start.Nanosecond()/1000/1000,
+		end.Hour(), end.Minute(), end.Second(), end.Nanosecond()/1000/1000,
+	)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSubTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        string
+		wantStart time.Duration
+		wantEnd   time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "comma millisecond separator",
+			in:        "00:00:01,500 --> 00:00:04,000",
+			wantStart: 1500 * time.Millisecond,
+			wantEnd:   4 * time.Second,
+		},
+		{
+			name:      "dot millisecond separator",
+			in:        "00:00:01.500 --> 00:00:04.000",
+			wantStart: 1500 * time.Millisecond,
+			wantEnd:   4 * time.Second,
+		},
+		{
+			name:      "trailing position directive is ignored",
+			in:        "00:00:01,000 --> 00:00:04,000 X1:100 X2:200 Y1:300 Y2:400",
+			wantStart: time.Second,
+			wantEnd:   4 * time.Second,
+		},
+		{
+			name:    "malformed line",
+			in:      "not a timing line",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseSubTime(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSubTime(%q) expected error, got nil", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSubTime(%q) unexpected error: %v", tt.in, err)
+			}
+
+			if got := start.Sub(ZeroTime); got != tt.wantStart {
+				t.Errorf("start = %v, want %v", got, tt.wantStart)
+			}
+			if got := end.Sub(ZeroTime); got != tt.wantEnd {
+				t.Errorf("end = %v, want %v", got, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestSrtFormatReadHandlesMissingIndexAndBOM(t *testing.T) {
+	input := "\uFEFF00:00:01,000 --> 00:00:02,000\r\nHello\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nWorld\r\n"
+
+	subs, err := srtFormat{}.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+
+	if len(subs.Cues) != 2 {
+		t.Fatalf("got %d cues, want 2", len(subs.Cues))
+	}
+	if got := subs.Cues[0].text; len(got) != 1 || got[0] != "Hello" {
+		t.Errorf("first cue text = %v, want [Hello]", got)
+	}
+	if got := subs.Cues[1].text; len(got) != 1 || got[0] != "World" {
+		t.Errorf("second cue text = %v, want [World]", got)
+	}
+}
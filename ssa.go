@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const ssaDialoguePrefix = "Dialogue:"
+
+// ssaFormat implements Reader and Writer for SubStation Alpha (.ssa/.ass)
+// files. Only the [Events] Dialogue lines are round-tripped; styles,
+// script info and other sections are ignored on read and omitted on
+// write beyond the minimum needed to make the file valid.
+type ssaFormat struct{}
+
+// Read loads SSA/ASS dialogue lines as cues.
+func (ssaFormat) Read(r io.Reader) (*Subtitles, error) {
+	subs := NewSubtitles()
+	scanner := bufio.NewScanner(NewReader(r))
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if !strings.HasPrefix(line, ssaDialoguePrefix) {
+			continue
+		}
+
+		cue, err := parseSsaDialogue(strings.TrimPrefix(line, ssaDialoguePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("can't parse ssa dialogue %q: %s", line, err)
+		}
+		subs.Cues = append(subs.Cues, cue)
+	}
+
+	return subs, nil
+}
+
+// Write writes subs as a minimal SSA file containing only the sections
+// required for the result to be valid: [Script Info] and [Events].
+func (ssaFormat) Write(w io.Writer, subs *Subtitles) error {
+	var buf strings.Builder
+	buf.WriteString("[Script Info]" + newLine)
+	buf.WriteString("ScriptType: v4.00+" + newLine + newLine)
+	buf.WriteString("[Events]" + newLine)
+	buf.WriteString("Format: Marked, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text" + newLine)
+
+	for _, cue := range subs.Cues {
+		buf.WriteString(formatSsaDialogue(cue) + newLine)
+	}
+
+	_, err := w.Write([]byte(buf.String()))
+	return err
+}
+
+// parseSsaDialogue parses the comma-separated fields following
+// "Dialogue:" into a Cue. Text may itself contain commas, so it is
+// reassembled from the 10th field onward per the SSA spec.
+func parseSsaDialogue(fields string) (*Cue, error) {
+	parts := strings.SplitN(fields, ",", 10)
+	if len(parts) < 10 {
+		return nil, fmt.Errorf("expected 10 fields, got %d", len(parts))
+	}
+
+	start, err := parseSsaTimestamp(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := parseSsaTimestamp(strings.TrimSpace(parts[2]))
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.Split(strings.ReplaceAll(parts[9], `\N`, "\n"), "\n")
+
+	return &Cue{start: start, end: end, text: text}, nil
+}
+
+// formatSsaDialogue renders a cue as a "Dialogue:" line.
+func formatSsaDialogue(cue *Cue) string {
+	text := strings.Join(cue.text, `\N`)
+
+	return fmt.Sprintf("Dialogue: 0,%s,%s,Default,,0,0,0,,%s",
+		formatSsaTimestamp(cue.start), formatSsaTimestamp(cue.end), text)
+}
+
+// parseSsaTimestamp parses an SSA "H:MM:SS.cc" timestamp (centiseconds)
+// into a time anchored at ZeroTime.
+func parseSsaTimestamp(str string) (time.Time, error) {
+	var h, m, s, cs int
+	_, err := fmt.Sscanf(str, "%d:%d:%d.%d", &h, &m, &s, &cs)
+	if err != nil {
+		return ZeroTime, err
+	}
+
+	return ZeroTime.Add(
+		time.Duration(h)*time.Hour +
+			time.Duration(m)*time.Minute +
+			time.Duration(s)*time.Second +
+			time.Duration(cs)*10*time.Millisecond,
+	), nil
+}
+
+// formatSsaTimestamp formats t as an SSA "H:MM:SS.cc" timestamp.
+func formatSsaTimestamp(t time.Time) string {
+	if t.Before(ZeroTime) {
+		t = ZeroTime
+	}
+
+	return fmt.Sprintf("%d:%02d:%02d.%02d",
+		int(t.Sub(ZeroTime).Hours()), t.Minute(), t.Second(), t.Nanosecond()/1000/1000/10)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSsaTimestamp(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "zero", in: "0:00:00.00", want: 0},
+		{name: "hours minutes seconds centiseconds", in: "1:02:03.45", want: time.Hour + 2*time.Minute + 3*time.Second + 450*time.Millisecond},
+		{name: "invalid", in: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSsaTimestamp(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSsaTimestamp(%q) expected error, got nil", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSsaTimestamp(%q) unexpected error: %v", tt.in, err)
+			}
+			if d := got.Sub(ZeroTime); d != tt.want {
+				t.Errorf("parseSsaTimestamp(%q) = %v, want %v", tt.in, d, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSsaDialogue(t *testing.T) {
+	cue, err := parseSsaDialogue(" 0,0:00:01.00,0:00:04.50,Default,,0,0,0,,Line one\\NLine two")
+	if err != nil {
+		t.Fatalf("parseSsaDialogue() unexpected error: %v", err)
+	}
+
+	if got := cue.start.Sub(ZeroTime); got != time.Second {
+		t.Errorf("start = %v, want 1s", got)
+	}
+	if got := cue.end.Sub(ZeroTime); got != 4500*time.Millisecond {
+		t.Errorf("end = %v, want 4.5s", got)
+	}
+	if want := []string{"Line one", "Line two"}; len(cue.text) != 2 || cue.text[0] != want[0] || cue.text[1] != want[1] {
+		t.Errorf("text = %v, want %v", cue.text, want)
+	}
+}
@@ -1,258 +1,187 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
 	"errors"
-	"fmt"
-	"io"
-	"io/ioutil"
-	"log"
-	"strings"
 	"time"
-	"unicode/utf8"
-
-	"golang.org/x/text/encoding/charmap"
-)
-
-var (
-	// BytesBOM         = []byte{239, 187, 191}
-	layout           = "15:04:05"
-	srtTimeSeparator = " --> "
-	newLine          = "\n"
-	ZeroTime, _      = time.Parse(layout, "00:00:00")
 )
 
 // example
 // func main() {
-// 	file, err := os.Open(subFile)
-// 	if err != nil {
-// 		log.Fatal(err)
-// 	}
-
-// 	srt := NewSrt()
-// 	err = srt.ReadSubtitles(file)
+// 	subs, err := Open(subFile)
 // 	if err != nil {
 // 		log.Fatal(err)
 // 	}
-
-// srt.ShiftAll(5500 * time.Millisecond)
-
-// srt.Write(os.Stdout)
+//
+// 	subs.ShiftAll(5500 * time.Millisecond)
+//
+// 	subs.WriteFile(outFile)
 // }
 
-// Srt wraps subtitle lines
-type Srt struct {
-	Subtitles []*Subtitle
-}
+var (
+	layout      = "15:04:05"
+	ZeroTime, _ = time.Parse(layout, "00:00:00")
+)
 
-// Subtitle holds each subtitle text data
-type Subtitle struct {
+// Style holds format-neutral per-cue styling metadata. Readers populate
+// whatever they can express and writers emit whatever their format
+// supports; fields that don't translate are simply dropped.
+//
+// Only Position is wired up: SRT's X1/X2/Y1/Y2 directive and VTT's cue
+// settings both round-trip through it. Color/bold/italic/underline were
+// scoped out of this pass rather than deferred silently - none of SRT,
+// VTT or SSA's Reader/Writer in this package populate or consume them
+// today, so add the field alongside the Reader/Writer code that actually
+// does, rather than ahead of it.
+type Style struct {
+	Position string
+}
+
+// Cue is a single timed subtitle entry.
+type Cue struct {
 	start time.Time
 	end   time.Time
 	text  []string
+	style *Style
 }
 
-// NewReader returns utf-8 compatible Reader
-func NewReader(r io.Reader) io.Reader {
-	var buff bytes.Buffer
-	newReader := io.TeeReader(r, &buff)
-
-	data, err := ioutil.ReadAll(newReader)
-	if err != nil {
-		log.Fatal(err)
-	}
-	if utf8.Valid(data) {
-		return &buff
-	}
-
-	return charmap.Windows1256.NewDecoder().Reader(&buff)
+// Subtitles holds a sequence of timed cues, independent of source format.
+type Subtitles struct {
+	Cues []*Cue
 }
 
-// NewSrt returns a Srt
-func NewSrt() *Srt {
-	return &Srt{
-		Subtitles: make([]*Subtitle, 0),
+// NewSubtitles returns an empty Subtitles.
+func NewSubtitles() *Subtitles {
+	return &Subtitles{
+		Cues: make([]*Cue, 0),
 	}
 }
 
-// ReadSubtitles loads subtitle lines
-func (s *Srt) ReadSubtitles(r io.Reader) error {
-
-	reader := NewReader(r)
-	scanner := bufio.NewScanner(reader)
-
-	sub := &Subtitle{}
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.Contains(line, srtTimeSeparator) {
-			// remove index of subtitle
-			sub.text = sub.text[:len(sub.text)-1]
-
-			if len(sub.text) > 0 {
-				if len(sub.text[len(sub.text)-1]) == 0 {
-					sub.text = sub.text[:len(sub.text)-1]
-				}
-			}
-
-			start, end, err := parseSubTime(line)
-			if err != nil {
-				return fmt.Errorf("can't parse srt duration %s, %s", line, err)
-			}
-			sub = &Subtitle{
-				start: start,
-				end:   end,
-			}
-
-			s.Subtitles = append(s.Subtitles, sub)
-			sub.text = make([]string, 0)
-		} else {
-			sub.text = append(sub.text, line)
-		}
+// ShiftAll shifts all cues by given duration
+// e.g.   move all cues +2 seconds forward
+func (s *Subtitles) ShiftAll(dur time.Duration) {
+	for _, cue := range s.Cues {
+		cue.Shift(dur)
 	}
-	return nil
 }
 
-// Write writes formatted subtitles to a writer
-func (s *Srt) Write(w io.Writer) error {
-	if len(s.Subtitles) == 0 {
-		return errors.New("no subtitles to write")
-	}
-
-	var buf strings.Builder
-	// buf.Write(BytesBOM)
-
-	for i, sub := range s.Subtitles {
-		buf.WriteString(fmt.Sprintf("%d", i+1) + newLine)
-		buf.WriteString(formatSubTime(sub.start, sub.end) + newLine)
-		for _, line := range sub.text {
-			buf.WriteString(line + newLine)
+// ShiftPart shifts cues between given start and end time
+// e.g.   move cues between start and end by -2 second
+func (s *Subtitles) ShiftPart(start, end time.Time, dur time.Duration) {
+	for _, cue := range s.Cues {
+		if cue.start.After(start) && cue.end.Before(end) {
+			cue.Shift(dur)
 		}
-		buf.WriteString(newLine)
 	}
-
-	if _, err := w.Write([]byte(buf.String())); err != nil {
-		return err
-	}
-
-	return nil
 }
 
-// parseSubTime reads subtitle time to go time
-func parseSubTime(str string) (start, end time.Time, err error) {
-	var h1, m1, s1, ms1, h2, m2, s2, ms2 time.Duration
-	start, end = ZeroTime, ZeroTime
-
-	_, err = fmt.Sscanf(str, "%d:%d:%d,%d --> %d:%d:%d,%d",
-		&h1, &m1, &s1, &ms1,
-		&h2, &m2, &s2, &ms2)
-	if err != nil {
-		return
-	}
-
-	start = start.Add(h1*time.Hour + m1*time.Minute + s1*time.Second + ms1*time.Millisecond)
-	end = end.Add(h2*time.Hour + m2*time.Minute + s2*time.Second + ms2*time.Millisecond)
-
-	return
-}
-
-// formatSubTime formats given start/end times to subtitle time format
-func formatSubTime(start time.Time, end time.Time) string {
-	if start.Before(ZeroTime) {
-		start = ZeroTime
-	}
-
-	return fmt.Sprintf("%02d:%02d:%02d,%03d --> %02d:%02d:%02d,%03d",
-		start.Hour(), start.Minute(), start.Second(), start.Nanosecond()/1000/1000,
-		end.Hour(), end.Minute(), end.Second(), end.Nanosecond()/1000/1000,
-	)
-}
-
-// ShiftAll shifts all subtitles by given duration
-// e.g.   move all subtitles +2 seconds forward
-func (s *Srt) ShiftAll(dur time.Duration) {
-	for _, sub := range s.Subtitles {
-		sub.Shift(dur)
-	}
-}
-
-// ShiftPart shifts subtitles between given start and end time
-// e.g.   move subtitles between start and end by -2 second
-func (s *Srt) ShiftPart(start, end time.Time, dur time.Duration) {
-	for _, sub := range s.Subtitles {
-		if sub.start.After(start) && sub.end.Before(end) {
-			sub.Shift(dur)
-		}
-	}
-}
-
-// Shift shifts subtitle time by duration
+// Shift shifts cue time by duration
 // e.g. -2/+2 seconds
-func (sub *Subtitle) Shift(dur time.Duration) {
-	if sub.start.Add(dur).After(ZeroTime) {
-		sub.start = sub.start.Add(dur)
+func (c *Cue) Shift(dur time.Duration) {
+	if c.start.Add(dur).After(ZeroTime) {
+		c.start = c.start.Add(dur)
 	}
-	sub.end = sub.end.Add(dur)
+	c.end = c.end.Add(dur)
 }
 
-// ShiftStart shifts only start time of subtitle by duration
-func (sub *Subtitle) ShiftStart(dur time.Duration) {
-	if sub.start.Add(dur).After(ZeroTime) {
-		sub.start = sub.start.Add(dur)
+// ShiftStart shifts only start time of cue by duration
+func (c *Cue) ShiftStart(dur time.Duration) {
+	if c.start.Add(dur).After(ZeroTime) {
+		c.start = c.start.Add(dur)
 	}
 }
 
-// ShiftStart shifts only end time of subtitle by duration
-func (sub *Subtitle) ShiftEnd(dur time.Duration) {
-	sub.end = sub.end.Add(dur)
+// ShiftEnd shifts only end time of cue by duration
+func (c *Cue) ShiftEnd(dur time.Duration) {
+	c.end = c.end.Add(dur)
 }
 
-// CutPart removes subtitles between start time and end time
-func (s *Srt) CutPart(start, end time.Time) {
-	newSubs := make([]*Subtitle, 0)
+// CutPart removes cues between start time and end time
+func (s *Subtitles) CutPart(start, end time.Time) {
+	newCues := make([]*Cue, 0)
 	dur := end.Sub(start)
 
-	for _, sub := range s.Subtitles {
-		if sub.start.After(start) && sub.end.Before(end) {
+	for _, cue := range s.Cues {
+		if cue.start.After(start) && cue.end.Before(end) {
 			continue
 		}
-		if sub.end.After(end) {
-			sub.Shift(dur)
+		if cue.end.After(end) {
+			cue.Shift(dur)
 		}
-		newSubs = append(newSubs, sub)
+		newCues = append(newCues, cue)
 	}
 
-	s.Subtitles = newSubs
+	s.Cues = newCues
 }
 
-// ShiftSync shifts subtitles relatively by duration
+// ShiftSync shifts cues relatively by duration
 // given 20 seconds means it shifts zero seconds to
-// first subtitle and 20 seconds to last one. And all
-// subtitles between shift relatively to the whole
+// first cue and 20 seconds to last one. And all
+// cues between shift relatively to the whole
 // duration of file.
-func (s *Srt) ShiftSync(changeDur time.Duration) {
-	lastSub := s.Subtitles[len(s.Subtitles)-1]
-	totalDur := lastSub.end.Sub(ZeroTime)
+func (s *Subtitles) ShiftSync(changeDur time.Duration) {
+	lastCue := s.Cues[len(s.Cues)-1]
+	totalDur := lastCue.end.Sub(ZeroTime)
 	totalDurMil := totalDur.Nanoseconds() / 1000 / 1000
 	changeDurMil := changeDur.Nanoseconds() / 1000 / 1000
 
-	for _, sub := range s.Subtitles {
-		startDurMil := sub.start.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
+	for _, cue := range s.Cues {
+		startDurMil := cue.start.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
 		startDiff := float64(startDurMil) / float64(totalDurMil) * float64(changeDurMil)
 		durStart := time.Duration(startDiff) * time.Millisecond
 
-		endDurMil := sub.end.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
+		endDurMil := cue.end.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
 		endDiff := float64(endDurMil) / float64(totalDurMil) * float64(changeDurMil)
 		durEnd := time.Duration(endDiff) * time.Millisecond
 
-		sub.ShiftStart(durStart)
-		sub.ShiftEnd(durEnd)
+		cue.ShiftStart(durStart)
+		cue.ShiftEnd(durEnd)
 	}
 }
 
-// StripTags removes html tags from subtitle
-func (s *Srt) StripTags() error {
-	// implement stripeTags to remove html tags
+// SyncByAnchors re-times every cue using a linear transformation so
+// that the first cue begins exactly at firstStart and the last cue
+// begins exactly at lastStart, with every cue in between interpolated
+// proportionally. It is strictly more general than ShiftSync, which only
+// scales durations from zero.
+func (s *Subtitles) SyncByAnchors(firstStart, lastStart time.Time) error {
+	if len(s.Cues) < 2 {
+		return errors.New("need at least two cues to anchor sync")
+	}
+
+	first := s.Cues[0]
+	last := s.Cues[len(s.Cues)-1]
+
+	o1 := first.start.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
+	oN := last.start.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
+	if oN == o1 {
+		return errors.New("first and last cue share the same start time")
+	}
+
+	t1 := firstStart.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
+	tN := lastStart.Sub(ZeroTime).Nanoseconds() / 1000 / 1000
+
+	a := float64(tN-t1) / float64(oN-o1)
+	b := float64(t1) - a*float64(o1)
+
+	for _, cue := range s.Cues {
+		cue.start = anchorTime(cue.start, a, b)
+		cue.end = anchorTime(cue.end, a, b)
+	}
+
 	return nil
 }
+
+// anchorTime applies the linear transform new = a*old + b (in
+// milliseconds) to t, clamping negative results to ZeroTime the way
+// Shift does.
+func anchorTime(t time.Time, a, b float64) time.Time {
+	oldMil := float64(t.Sub(ZeroTime).Nanoseconds() / 1000 / 1000)
+	newMil := a*oldMil + b
+
+	newTime := ZeroTime.Add(time.Duration(newMil) * time.Millisecond)
+	if newTime.Before(ZeroTime) {
+		return ZeroTime
+	}
+	return newTime
+}
+
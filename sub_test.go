@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncByAnchors(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 1, "one"),
+		cueAt(5, 6, "two"),
+		cueAt(10, 11, "three"),
+	}}
+
+	err := subs.SyncByAnchors(ZeroTime.Add(2*time.Second), ZeroTime.Add(22*time.Second))
+	if err != nil {
+		t.Fatalf("SyncByAnchors() unexpected error: %v", err)
+	}
+
+	if got := subs.Cues[0].start.Sub(ZeroTime); got != 2*time.Second {
+		t.Errorf("first cue start = %v, want 2s", got)
+	}
+	if got := subs.Cues[2].start.Sub(ZeroTime); got != 22*time.Second {
+		t.Errorf("last cue start = %v, want 22s", got)
+	}
+	if got := subs.Cues[1].start.Sub(ZeroTime); got != 12*time.Second {
+		t.Errorf("middle cue start = %v, want 12s", got)
+	}
+}
+
+func TestSyncByAnchorsClampsNegativeResultsToZero(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(10, 11, "one"),
+		cueAt(20, 21, "two"),
+	}}
+
+	if err := subs.SyncByAnchors(ZeroTime.Add(-5*time.Second), ZeroTime.Add(5*time.Second)); err != nil {
+		t.Fatalf("SyncByAnchors() unexpected error: %v", err)
+	}
+
+	if !subs.Cues[0].start.Equal(ZeroTime) {
+		t.Errorf("first cue start = %v, want clamped to ZeroTime", subs.Cues[0].start)
+	}
+}
+
+func TestSyncByAnchorsRequiresTwoCues(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{cueAt(0, 1, "one")}}
+
+	if err := subs.SyncByAnchors(ZeroTime, ZeroTime.Add(time.Second)); err == nil {
+		t.Fatal("expected error with fewer than two cues")
+	}
+}
+
+func TestSyncByAnchorsRequiresDistinctStarts(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		cueAt(0, 1, "one"),
+		cueAt(0, 2, "two"),
+	}}
+
+	if err := subs.SyncByAnchors(ZeroTime, ZeroTime.Add(time.Second)); err == nil {
+		t.Fatal("expected error when first and last cue share a start time")
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTagRe = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	ssaTagRe  = regexp.MustCompile(`\{[^}]*\}`)
+)
+
+// StripTagsOptions selects which kinds of styling markup StripTags
+// removes.
+type StripTagsOptions struct {
+	// HTML strips tags like <i>, <b>, <u> and <font color=...> and
+	// their closers.
+	HTML bool
+	// SSA strips SSA/ASS override blocks like {\an8} or {\pos(100,200)}.
+	SSA bool
+	// PositionHints clears the position hint captured from a cue's
+	// timing line (e.g. SubRip's X1/X2/Y1/Y2 directives).
+	PositionHints bool
+}
+
+// StripTags removes styling markup from every cue's text according to
+// opts.
+func (s *Subtitles) StripTags(opts StripTagsOptions) error {
+	for _, cue := range s.Cues {
+		for i, line := range cue.text {
+			if opts.HTML {
+				line = htmlTagRe.ReplaceAllString(line, "")
+			}
+			if opts.SSA {
+				line = ssaTagRe.ReplaceAllString(line, "")
+			}
+			cue.text[i] = line
+		}
+
+		if opts.PositionHints && cue.style != nil {
+			cue.style.Position = ""
+		}
+	}
+
+	return nil
+}
+
+// PlainText returns the cue's text joined with newlines and all HTML
+// and SSA tags removed, for downstream NLP/indexing use.
+func (c *Cue) PlainText() string {
+	lines := make([]string, len(c.text))
+	for i, line := range c.text {
+		line = htmlTagRe.ReplaceAllString(line, "")
+		line = ssaTagRe.ReplaceAllString(line, "")
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
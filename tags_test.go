@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestStripTagsHTML(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		{text: []string{"<i>italic</i> and <font color=\"red\">red</font>"}},
+	}}
+
+	if err := subs.StripTags(StripTagsOptions{HTML: true}); err != nil {
+		t.Fatalf("StripTags() unexpected error: %v", err)
+	}
+
+	if want := "italic and red"; subs.Cues[0].text[0] != want {
+		t.Errorf("text = %q, want %q", subs.Cues[0].text[0], want)
+	}
+}
+
+func TestStripTagsSSA(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		{text: []string{`{\an8}Top of screen`}},
+	}}
+
+	if err := subs.StripTags(StripTagsOptions{SSA: true}); err != nil {
+		t.Fatalf("StripTags() unexpected error: %v", err)
+	}
+
+	if want := "Top of screen"; subs.Cues[0].text[0] != want {
+		t.Errorf("text = %q, want %q", subs.Cues[0].text[0], want)
+	}
+}
+
+func TestStripTagsPositionHints(t *testing.T) {
+	subs := &Subtitles{Cues: []*Cue{
+		{text: []string{"hello"}, style: &Style{Position: "X1:100 X2:200 Y1:300 Y2:400"}},
+	}}
+
+	if err := subs.StripTags(StripTagsOptions{PositionHints: true}); err != nil {
+		t.Fatalf("StripTags() unexpected error: %v", err)
+	}
+
+	if got := subs.Cues[0].style.Position; got != "" {
+		t.Errorf("Position = %q, want cleared", got)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	cue := &Cue{text: []string{`{\an8}<i>Hello</i>`, "World"}}
+
+	if got, want := cue.PlainText(), "Hello\nWorld"; got != want {
+		t.Errorf("PlainText() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const vttHeader = "WEBVTT"
+
+// vttFormat implements Reader and Writer for WebVTT (.vtt) files.
+type vttFormat struct{}
+
+// Read loads WebVTT cues. A numeric cue identifier line is discarded,
+// mirroring how SubRip index lines are dropped: once a timing line
+// arrives, the preceding cue's last text line is stripped if it parses
+// as an integer. A non-numeric identifier is not recognised: it is kept
+// as the last text line of the preceding cue, or skipped if no cue has
+// started yet.
+func (vttFormat) Read(r io.Reader) (*Subtitles, error) {
+	subs := NewSubtitles()
+	scanner := bufio.NewScanner(NewReader(r))
+
+	var cue *Cue
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == vttHeader || strings.HasPrefix(line, vttHeader+" ") {
+			continue
+		}
+
+		if strings.Contains(line, "-->") {
+			if cue != nil && len(cue.text) > 0 {
+				if _, err := strconv.Atoi(strings.TrimSpace(cue.text[len(cue.text)-1])); err == nil {
+					cue.text = cue.text[:len(cue.text)-1]
+				}
+			}
+
+			start, end, style, err := parseVttTime(line)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse vtt timing %q: %s", line, err)
+			}
+			cue = &Cue{start: start, end: end, style: style, text: make([]string, 0)}
+			subs.Cues = append(subs.Cues, cue)
+			continue
+		}
+
+		if line == "" || cue == nil {
+			continue
+		}
+
+		cue.text = append(cue.text, line)
+	}
+
+	return subs, nil
+}
+
+// Write writes subs as a WebVTT file.
+func (vttFormat) Write(w io.Writer, subs *Subtitles) error {
+	var buf strings.Builder
+	buf.WriteString(vttHeader + newLine + newLine)
+
+	for _, cue := range subs.Cues {
+		buf.WriteString(formatVttTime(cue.start, cue.end))
+		if cue.style != nil && cue.style.Position != "" && !srtPositionRe.MatchString(cue.style.Position) {
+			buf.WriteString(" " + cue.style.Position)
+		}
+		buf.WriteString(newLine)
+		for _, line := range cue.text {
+			buf.WriteString(line + newLine)
+		}
+		buf.WriteString(newLine)
+	}
+
+	_, err := w.Write([]byte(buf.String()))
+	return err
+}
+
+// parseVttTime parses a WebVTT timing line, e.g.
+// "00:00:01.000 --> 00:00:04.000 position:10%,end", returning any
+// trailing cue settings as a Style.
+func parseVttTime(line string) (start, end time.Time, style *Style, err error) {
+	parts := strings.SplitN(line, "-->", 2)
+	if len(parts) != 2 {
+		err = fmt.Errorf("missing --> separator")
+		return
+	}
+
+	start, err = parseVttTimestamp(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return
+	}
+
+	rest := strings.Fields(strings.TrimSpace(parts[1]))
+	if len(rest) == 0 {
+		err = fmt.Errorf("missing end timestamp")
+		return
+	}
+
+	end, err = parseVttTimestamp(rest[0])
+	if err != nil {
+		return
+	}
+
+	if len(rest) > 1 {
+		style = &Style{Position: strings.Join(rest[1:], " ")}
+	}
+
+	return
+}
+
+// parseVttTimestamp parses a WebVTT "HH:MM:SS.mmm" (or "MM:SS.mmm")
+// timestamp into a time anchored at ZeroTime.
+func parseVttTimestamp(str string) (time.Time, error) {
+	fields := strings.Split(str, ":")
+
+	var h, m int
+	var secStr string
+	var err error
+
+	switch len(fields) {
+	case 3:
+		if h, err = strconv.Atoi(fields[0]); err != nil {
+			return ZeroTime, err
+		}
+		if m, err = strconv.Atoi(fields[1]); err != nil {
+			return ZeroTime, err
+		}
+		secStr = fields[2]
+	case 2:
+		if m, err = strconv.Atoi(fields[0]); err != nil {
+			return ZeroTime, err
+		}
+		secStr = fields[1]
+	default:
+		return ZeroTime, fmt.Errorf("invalid timestamp %q", str)
+	}
+
+	secFields := strings.SplitN(secStr, ".", 2)
+	sec, err := strconv.Atoi(secFields[0])
+	if err != nil {
+		return ZeroTime, err
+	}
+
+	var ms int
+	if len(secFields) == 2 {
+		if ms, err = strconv.Atoi(secFields[1]); err != nil {
+			return ZeroTime, err
+		}
+	}
+
+	return ZeroTime.Add(
+		time.Duration(h)*time.Hour +
+			time.Duration(m)*time.Minute +
+			time.Duration(sec)*time.Second +
+			time.Duration(ms)*time.Millisecond,
+	), nil
+}
+
+// formatVttTime formats a cue's start/end as a WebVTT timing line.
+func formatVttTime(start, end time.Time) string {
+	if start.Before(ZeroTime) {
+		start = ZeroTime
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d.%03d --> %02d:%02d:%02d.%03d",
+		start.Hour(), start.Minute(), start.Second(), start.Nanosecond()/1000/1000,
+		end.Hour(), end.Minute(), end.Second(), end.Nanosecond()/1000/1000,
+	)
+}
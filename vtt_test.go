@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseVttTimestamp(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "hours minutes seconds ms", in: "00:00:01.500", want: 1500 * time.Millisecond},
+		{name: "minutes seconds ms", in: "01:02.250", want: time.Minute + 2*time.Second + 250*time.Millisecond},
+		{name: "no fractional part", in: "00:00:05", want: 5 * time.Second},
+		{name: "invalid", in: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVttTimestamp(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseVttTimestamp(%q) expected error, got nil", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseVttTimestamp(%q) unexpected error: %v", tt.in, err)
+			}
+			if d := got.Sub(ZeroTime); d != tt.want {
+				t.Errorf("parseVttTimestamp(%q) = %v, want %v", tt.in, d, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVttTime(t *testing.T) {
+	start, end, style, err := parseVttTime("00:00:01.000 --> 00:00:04.000 position:10%,end")
+	if err != nil {
+		t.Fatalf("parseVttTime() unexpected error: %v", err)
+	}
+	if got := start.Sub(ZeroTime); got != time.Second {
+		t.Errorf("start = %v, want 1s", got)
+	}
+	if got := end.Sub(ZeroTime); got != 4*time.Second {
+		t.Errorf("end = %v, want 4s", got)
+	}
+	if style == nil || style.Position != "position:10%,end" {
+		t.Errorf("style = %+v, want Position %q", style, "position:10%,end")
+	}
+}
+
+func TestParseVttTimeMissingSeparator(t *testing.T) {
+	if _, _, _, err := parseVttTime("00:00:01.000 00:00:04.000"); err == nil {
+		t.Fatal("expected error for missing --> separator")
+	}
+}
+
+func TestVttFormatReadHandlesCueIdentifiers(t *testing.T) {
+	input := "WEBVTT\n\n1\n00:00:01.000 --> 00:00:02.000\nHello\n\n2\n00:00:03.000 --> 00:00:04.000\nWorld\n"
+
+	subs, err := vttFormat{}.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+
+	if len(subs.Cues) != 2 {
+		t.Fatalf("got %d cues, want 2", len(subs.Cues))
+	}
+	if got := subs.Cues[0].text; len(got) != 1 || got[0] != "Hello" {
+		t.Errorf("first cue text = %v, want [Hello]", got)
+	}
+	if got := subs.Cues[1].text; len(got) != 1 || got[0] != "World" {
+		t.Errorf("second cue text = %v, want [World]", got)
+	}
+}